@@ -0,0 +1,23 @@
+// Command epss-sync fetches the daily FIRST.org EPSS feed and upserts
+// it onto the cve table, meant to run as a nightly cron job alongside
+// the other enrichment/feed sync commands.
+package main
+
+import (
+	"app/manager/base/dbconn"
+	"app/manager/base/epss"
+	"log"
+	"time"
+)
+
+func main() {
+	conn, err := dbconn.Open()
+	if err != nil {
+		log.Fatalf("epss-sync: %s", err.Error())
+	}
+
+	if err := epss.Sync(conn, epss.NewFetcher(), time.Now()); err != nil {
+		log.Fatalf("epss-sync: %s", err.Error())
+	}
+	log.Print("epss-sync: done")
+}
@@ -0,0 +1,31 @@
+// Command mitre-sync enriches CVEs that have never been synced from
+// MITRE's CVE Services v5 API, meant to run as a nightly cron job
+// alongside the other enrichment/feed sync commands.
+package main
+
+import (
+	"app/manager/base/dbconn"
+	"app/manager/base/enrich/mitre"
+	"log"
+)
+
+// batchSize bounds how many never-enriched CVEs a single run pulls,
+// keeping one run's MITRE API usage predictable.
+const batchSize = 500
+
+func main() {
+	conn, err := dbconn.Open()
+	if err != nil {
+		log.Fatalf("mitre-sync: %s", err.Error())
+	}
+
+	cveNames, err := mitre.PendingCveNames(conn, batchSize)
+	if err != nil {
+		log.Fatalf("mitre-sync: %s", err.Error())
+	}
+
+	if err := mitre.Sync(conn, mitre.NewFetcher(), cveNames, mitre.DefaultPrecedence); err != nil {
+		log.Printf("mitre-sync: %s", err.Error())
+	}
+	log.Printf("mitre-sync: processed %d CVEs", len(cveNames))
+}
@@ -0,0 +1,31 @@
+// Command notify-sync dispatches notifications for every account with
+// at least one registered channel, meant to run as a nightly cron job
+// right after the cluster/image CVE refresh job.
+package main
+
+import (
+	"app/manager/base/dbconn"
+	"app/manager/base/notify"
+	"context"
+	"log"
+)
+
+func main() {
+	conn, err := dbconn.Open()
+	if err != nil {
+		log.Fatalf("notify-sync: %s", err.Error())
+	}
+
+	accountIDs, err := notify.AccountIDsWithChannels(conn)
+	if err != nil {
+		log.Fatalf("notify-sync: %s", err.Error())
+	}
+
+	ctx := context.Background()
+	for _, accountID := range accountIDs {
+		if err := notify.SyncAccount(ctx, conn, accountID); err != nil {
+			log.Printf("notify-sync: account %d: %s", accountID, err.Error())
+		}
+	}
+	log.Printf("notify-sync: processed %d accounts", len(accountIDs))
+}
@@ -0,0 +1,60 @@
+package base
+
+import (
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+// filterApplier is implemented by Filter values that translate
+// directly into a WHERE/JOIN clause, as opposed to filters such as
+// Sort/Limit/Offset/DataFormat/Report that only affect how ListQuery's
+// buffered result set is built.
+type filterApplier interface {
+	Apply(tx *gorm.DB) *gorm.DB
+}
+
+// ApplyFilters parses every allowed filter present in rawFilters and
+// applies it to query directly, for callers that stream rows from a
+// cursor instead of going through ListQuery's buffered result building.
+func ApplyFilters(query *gorm.DB, allowedFilters []string, rawFilters map[string][]string) (*gorm.DB, error) {
+	for _, name := range allowedFilters {
+		values, ok := rawFilters[name]
+		if !ok {
+			continue
+		}
+		filter, err := ParseFilter(name, values)
+		if err != nil {
+			return nil, err
+		}
+		if applier, ok := filter.(filterApplier); ok {
+			query = applier.Apply(query)
+		}
+	}
+	return query, nil
+}
+
+// ApplySort maps a `sort` filter onto ORDER BY clauses using the same
+// SortableColumns/DefaultSortable whitelist ListQuery applies to
+// buffered results, for callers such as StreamQuery that build their
+// own cursor-based query and never run it through ListQuery.
+func ApplySort(query *gorm.DB, args SortArgs, rawFilters map[string][]string) (*gorm.DB, error) {
+	items := args.DefaultSortable
+	if rawValues, ok := rawFilters[SortQuery]; ok {
+		if parsed := ParseSortArray(ParseCommaParams(rawValues)); len(parsed) > 0 {
+			items = parsed
+		}
+	}
+	for _, item := range items {
+		column, ok := args.SortableColumns[item.Column]
+		if !ok {
+			return nil, fmt.Errorf("base: unknown sort column %q", item.Column)
+		}
+		direction := "ASC"
+		if item.Desc {
+			direction = "DESC"
+		}
+		query = query.Order(fmt.Sprintf("%s %s", column, direction))
+	}
+	return query, nil
+}
@@ -0,0 +1,26 @@
+// Package dbconn opens the gorm connection shared by the manager's
+// one-shot sync commands (mitre-sync, epss-sync, notify-sync).
+package dbconn
+
+import (
+	"fmt"
+	"os"
+
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+// Open connects using the DATABASE_URL environment variable, the same
+// DSN the manager service itself expects to be configured with.
+func Open() (*gorm.DB, error) {
+	dsn := os.Getenv("DATABASE_URL")
+	if dsn == "" {
+		return nil, fmt.Errorf("dbconn: DATABASE_URL is not set")
+	}
+
+	conn, err := gorm.Open(postgres.Open(dsn), &gorm.Config{})
+	if err != nil {
+		return nil, fmt.Errorf("dbconn: connecting: %w", err)
+	}
+	return conn, nil
+}
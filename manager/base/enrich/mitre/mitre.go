@@ -0,0 +1,204 @@
+// Package mitre fetches CVE records from MITRE's CVE Services v5 JSON
+// schema (the cvelistV5 format) and maps them onto the fields stored in
+// the local cve table.
+package mitre
+
+import (
+	"app/base/models"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// BaseURL is the default root of the MITRE CVE Services v5 API.
+const BaseURL = "https://cveawg.mitre.org/api"
+
+// ErrNoEnglishDescription is returned when a CVE record has no
+// description in any language; a record with only non-english
+// descriptions uses the first of them instead.
+var ErrNoEnglishDescription = errors.New("mitre: no english description in cve record")
+
+// Record is the subset of the CVE Services v5 JSON 5.x schema this
+// package understands.
+type Record struct {
+	CveMetadata struct {
+		CveID         string    `json:"cveId"`
+		DatePublished time.Time `json:"datePublished"`
+	} `json:"cveMetadata"`
+	Containers struct {
+		CNA struct {
+			DatePublic   time.Time `json:"datePublic"`
+			Descriptions []struct {
+				Lang  string `json:"lang"`
+				Value string `json:"value"`
+			} `json:"descriptions"`
+			Metrics []struct {
+				CvssV3_1 *cvssMetric `json:"cvssV3_1"`
+				CvssV3_0 *cvssMetric `json:"cvssV3_0"`
+				CvssV2_0 *cvssMetric `json:"cvssV2_0"`
+			} `json:"metrics"`
+			ProblemTypes []struct {
+				Descriptions []struct {
+					CweID string `json:"cweId"`
+				} `json:"descriptions"`
+			} `json:"problemTypes"`
+			References []struct {
+				URL string `json:"url"`
+			} `json:"references"`
+		} `json:"cna"`
+	} `json:"containers"`
+}
+
+type cvssMetric struct {
+	BaseScore    float32 `json:"baseScore"`
+	BaseSeverity string  `json:"baseSeverity"`
+}
+
+// Enriched holds the fields extracted from a Record. Upsert persists
+// Description, Severity, Cvss2Score, Cvss3Score and LastEnrichedAt onto
+// the cve table; PublicDate, CWEs and References are extracted but not
+// written by Upsert.
+type Enriched struct {
+	Description    string
+	Cvss2Score     *float32
+	Cvss3Score     *float32
+	Severity       models.Severity
+	PublicDate     time.Time
+	CWEs           []string
+	References     []string
+	LastEnrichedAt time.Time
+}
+
+// Fetcher fetches CVE records from the MITRE CVE Services API.
+type Fetcher struct {
+	BaseURL    string
+	HTTPClient *http.Client
+}
+
+// NewFetcher creates a Fetcher with sensible defaults.
+func NewFetcher() *Fetcher {
+	return &Fetcher{BaseURL: BaseURL, HTTPClient: http.DefaultClient}
+}
+
+// FetchRecord fetches a single CVE record by ID, e.g. "CVE-2024-12345".
+func (f *Fetcher) FetchRecord(cveID string) (*Record, error) {
+	resp, err := f.HTTPClient.Get(fmt.Sprintf("%s/cve/%s", f.BaseURL, cveID))
+	if err != nil {
+		return nil, fmt.Errorf("mitre: fetching %s: %w", cveID, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("mitre: unexpected status %d fetching %s", resp.StatusCode, cveID)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("mitre: reading response for %s: %w", cveID, err)
+	}
+
+	var rec Record
+	if err := json.Unmarshal(body, &rec); err != nil {
+		return nil, fmt.Errorf("mitre: decoding response for %s: %w", cveID, err)
+	}
+	return &rec, nil
+}
+
+// Map converts a Record into the Enriched fields used to update the cve
+// table. When multiple CNAs publish CVSS3 metrics, the highest base
+// score wins; CVSS3.1 is preferred over CVSS3.0 when both are present.
+func Map(rec *Record) (Enriched, error) {
+	enriched := Enriched{LastEnrichedAt: time.Now().UTC()}
+
+	description, err := englishDescription(rec)
+	if err != nil {
+		return Enriched{}, err
+	}
+	enriched.Description = description
+
+	enriched.PublicDate = rec.Containers.CNA.DatePublic
+	if enriched.PublicDate.IsZero() {
+		enriched.PublicDate = rec.CveMetadata.DatePublished
+	}
+
+	var bestV3, bestV2 *cvssMetric
+	for _, metric := range rec.Containers.CNA.Metrics {
+		v3 := metric.CvssV3_1
+		if v3 == nil {
+			v3 = metric.CvssV3_0
+		}
+		if v3 != nil && (bestV3 == nil || v3.BaseScore > bestV3.BaseScore) {
+			bestV3 = v3
+		}
+		if metric.CvssV2_0 != nil && (bestV2 == nil || metric.CvssV2_0.BaseScore > bestV2.BaseScore) {
+			bestV2 = metric.CvssV2_0
+		}
+	}
+
+	var baseSeverity string
+	if bestV3 != nil {
+		score := bestV3.BaseScore
+		enriched.Cvss3Score = &score
+		baseSeverity = bestV3.BaseSeverity
+	}
+	if bestV2 != nil {
+		score := bestV2.BaseScore
+		enriched.Cvss2Score = &score
+		if baseSeverity == "" {
+			baseSeverity = bestV2.BaseSeverity
+		}
+	}
+	enriched.Severity = mapSeverity(baseSeverity)
+
+	for _, problemType := range rec.Containers.CNA.ProblemTypes {
+		for _, desc := range problemType.Descriptions {
+			if desc.CweID != "" {
+				enriched.CWEs = append(enriched.CWEs, desc.CweID)
+			}
+		}
+	}
+
+	for _, ref := range rec.Containers.CNA.References {
+		enriched.References = append(enriched.References, ref.URL)
+	}
+
+	return enriched, nil
+}
+
+func englishDescription(rec *Record) (string, error) {
+	var fallback string
+	for _, desc := range rec.Containers.CNA.Descriptions {
+		if desc.Lang == "en" {
+			return desc.Value, nil
+		}
+		if fallback == "" {
+			fallback = desc.Value
+		}
+	}
+	if fallback != "" {
+		return fallback, nil
+	}
+	return "", ErrNoEnglishDescription
+}
+
+// mapSeverity maps a CVSS baseSeverity string onto the local severity
+// enum, defaulting to NotSet when the value is unrecognized or absent.
+func mapSeverity(baseSeverity string) models.Severity {
+	switch baseSeverity {
+	case "NONE":
+		return models.None
+	case "LOW":
+		return models.Low
+	case "MEDIUM":
+		return models.Medium
+	case "HIGH":
+		return models.High
+	case "CRITICAL":
+		return models.Critical
+	default:
+		return models.NotSet
+	}
+}
@@ -0,0 +1,82 @@
+package mitre
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+const sampleRecordJSON = `{
+	"cveMetadata": {"cveId": "CVE-2024-12345", "datePublished": "2024-01-01T00:00:00Z"},
+	"containers": {
+		"cna": {
+			"datePublic": "2024-01-02T00:00:00Z",
+			"descriptions": [
+				{"lang": "fr", "value": "description francaise"},
+				{"lang": "en", "value": "english description"}
+			],
+			"metrics": [
+				{"cvssV3_1": {"baseScore": 7.5, "baseSeverity": "HIGH"}},
+				{"cvssV3_1": {"baseScore": 9.8, "baseSeverity": "CRITICAL"}}
+			],
+			"problemTypes": [{"descriptions": [{"cweId": "CWE-79"}]}],
+			"references": [{"url": "https://example.com/advisory"}]
+		}
+	}
+}`
+
+func decodeSample(t *testing.T) *Record {
+	t.Helper()
+	var rec Record
+	if err := json.Unmarshal([]byte(sampleRecordJSON), &rec); err != nil {
+		t.Fatalf("decoding sample record: %v", err)
+	}
+	return &rec
+}
+
+func TestMapPrefersEnglishDescriptionAndHighestCvss3(t *testing.T) {
+	enriched, err := Map(decodeSample(t))
+	if err != nil {
+		t.Fatalf("Map() error = %v", err)
+	}
+	if enriched.Description != "english description" {
+		t.Errorf("Description = %q, want english description", enriched.Description)
+	}
+	if enriched.Cvss3Score == nil || *enriched.Cvss3Score != 9.8 {
+		t.Errorf("Cvss3Score = %v, want 9.8", enriched.Cvss3Score)
+	}
+	if enriched.Severity != mapSeverity("CRITICAL") {
+		t.Errorf("Severity = %v, want %v", enriched.Severity, mapSeverity("CRITICAL"))
+	}
+	if len(enriched.CWEs) != 1 || enriched.CWEs[0] != "CWE-79" {
+		t.Errorf("CWEs = %v, want [CWE-79]", enriched.CWEs)
+	}
+}
+
+func TestMapWithoutEnglishDescriptionErrors(t *testing.T) {
+	rec := &Record{}
+	if _, err := Map(rec); err != ErrNoEnglishDescription {
+		t.Errorf("Map() error = %v, want ErrNoEnglishDescription", err)
+	}
+}
+
+func TestPrecedenceWins(t *testing.T) {
+	cases := []struct {
+		name            string
+		field           string
+		candidateSource string
+		currentSource   string
+		want            bool
+	}{
+		{"never enriched always wins", "severity", "mitre", "", true},
+		{"redhat outranks mitre on severity", "severity", "mitre", "redhat", false},
+		{"mitre outranks redhat on description", "description", "mitre", "redhat", true},
+		{"same source wins (re-sync)", "cvss3_score", "mitre", "mitre", true},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := DefaultPrecedence.Wins(tc.field, tc.candidateSource, tc.currentSource); got != tc.want {
+				t.Errorf("Wins(%q, %q, %q) = %v, want %v", tc.field, tc.candidateSource, tc.currentSource, got, tc.want)
+			}
+		})
+	}
+}
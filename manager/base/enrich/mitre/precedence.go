@@ -0,0 +1,42 @@
+package mitre
+
+// SourceName identifies this package as an enrichment source to the
+// per-field precedence map and the `source` column.
+const SourceName = "mitre"
+
+// Precedence ranks enrichment sources from most to least authoritative
+// per field. A field already populated by a source that outranks a
+// later sync's source is left untouched by that sync.
+type Precedence map[string][]string
+
+// DefaultPrecedence is the repo's default conflict-resolution policy:
+// Red Hat's own severity rating is trusted over upstream feeds, but
+// NVD's CVSS vectors and MITRE's descriptions are preferred over the
+// others when available.
+var DefaultPrecedence = Precedence{
+	"severity":    {"redhat", "nvd", "mitre"},
+	"cvss2_score": {"nvd", "mitre", "redhat"},
+	"cvss3_score": {"nvd", "mitre", "redhat"},
+	"description": {"mitre", "nvd", "redhat"},
+}
+
+// rank returns source's position in field's precedence list, or
+// len(list) (lowest priority) when source isn't ranked for that field.
+func (p Precedence) rank(field, source string) int {
+	for i, candidate := range p[field] {
+		if candidate == source {
+			return i
+		}
+	}
+	return len(p[field])
+}
+
+// Wins reports whether a value from candidateSource should overwrite
+// a field currently populated by currentSource. An empty currentSource
+// (field never enriched) always wins.
+func (p Precedence) Wins(field, candidateSource, currentSource string) bool {
+	if currentSource == "" {
+		return true
+	}
+	return p.rank(field, candidateSource) <= p.rank(field, currentSource)
+}
@@ -0,0 +1,106 @@
+package mitre
+
+import (
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+// currentRow is the subset of the cve table read back before an
+// upsert, so per-field precedence can be evaluated against whatever
+// source currently owns each field.
+type currentRow struct {
+	Source      string
+	Severity    string
+	Cvss2Score  *float32
+	Cvss3Score  *float32
+	Description string
+}
+
+// Upsert writes enriched onto the cve row named cveName, overwriting
+// only the fields precedence says mitre should own given the row's
+// current source. Rows that don't exist yet are left alone - MITRE
+// enrichment only updates CVEs that ingestion has already created.
+func Upsert(conn *gorm.DB, cveName string, enriched Enriched, precedence Precedence) error {
+	var current currentRow
+	res := conn.Table("cve").
+		Select("source, severity, cvss2_score, cvss3_score, description").
+		Where("name = ?", cveName).
+		Take(&current)
+	if res.Error == gorm.ErrRecordNotFound {
+		return nil
+	} else if res.Error != nil {
+		return fmt.Errorf("mitre: reading current row for %s: %w", cveName, res.Error)
+	}
+
+	updates := map[string]interface{}{
+		"last_enriched_at": enriched.LastEnrichedAt,
+	}
+	wonAnyField := false
+	if precedence.Wins("severity", SourceName, current.Source) {
+		updates["severity"] = enriched.Severity
+		wonAnyField = true
+	}
+	if precedence.Wins("cvss2_score", SourceName, current.Source) && enriched.Cvss2Score != nil {
+		updates["cvss2_score"] = *enriched.Cvss2Score
+		wonAnyField = true
+	}
+	if precedence.Wins("cvss3_score", SourceName, current.Source) && enriched.Cvss3Score != nil {
+		updates["cvss3_score"] = *enriched.Cvss3Score
+		wonAnyField = true
+	}
+	if precedence.Wins("description", SourceName, current.Source) {
+		updates["description"] = enriched.Description
+		wonAnyField = true
+	}
+	// source tracks whoever most recently won any field, so later syncs
+	// evaluate precedence against the actual current owner instead of
+	// forever comparing against the migration's default.
+	if wonAnyField {
+		updates["source"] = SourceName
+	}
+
+	if res := conn.Table("cve").Where("name = ?", cveName).Updates(updates); res.Error != nil {
+		return fmt.Errorf("mitre: upserting %s: %w", cveName, res.Error)
+	}
+	return nil
+}
+
+// Sync fetches and upserts enrichment for every CVE in cveNames,
+// skipping CVEs that fail to fetch, map or upsert so one bad record
+// doesn't abort the run. The returned error reports how many CVEs
+// failed and wraps the first failure only.
+func Sync(conn *gorm.DB, fetcher *Fetcher, cveNames []string, precedence Precedence) error {
+	var errs []error
+	for _, cveName := range cveNames {
+		rec, err := fetcher.FetchRecord(cveName)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		enriched, err := Map(rec)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if err := Upsert(conn, cveName, enriched, precedence); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("mitre: sync failed for %d/%d CVEs: %w", len(errs), len(cveNames), errs[0])
+	}
+	return nil
+}
+
+// PendingCveNames returns the names of CVEs that have never been
+// enriched (last_enriched_at IS NULL), the nightly sync command's
+// default work list.
+func PendingCveNames(conn *gorm.DB, limit int) ([]string, error) {
+	var names []string
+	res := conn.Table("cve").Select("name").Where("last_enriched_at IS NULL").Limit(limit).Find(&names)
+	if res.Error != nil {
+		return nil, fmt.Errorf("mitre: listing pending CVEs: %w", res.Error)
+	}
+	return names, nil
+}
@@ -0,0 +1,131 @@
+// Package epss fetches FIRST.org's daily Exploit Prediction Scoring
+// System feed and upserts it into the cve table's epss_score /
+// epss_percentile columns.
+package epss
+
+import (
+	"compress/gzip"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// FeedURLFormat is the daily FIRST.org EPSS feed URL, formatted with a
+// YYYY-MM-DD date.
+const FeedURLFormat = "https://epss.cyentia.com/epss_scores-%s.csv.gz"
+
+// Score is a single row of the EPSS feed: a CVE's exploit prediction
+// score and its percentile rank among all scored CVEs.
+type Score struct {
+	CveName    string
+	EpssScore  float32
+	Percentile float32
+}
+
+// Fetcher downloads and parses the daily EPSS feed.
+type Fetcher struct {
+	HTTPClient *http.Client
+}
+
+// NewFetcher creates a Fetcher with sensible defaults.
+func NewFetcher() *Fetcher {
+	return &Fetcher{HTTPClient: http.DefaultClient}
+}
+
+// FetchDaily downloads and parses the EPSS feed published for date.
+func (f *Fetcher) FetchDaily(date time.Time) ([]Score, error) {
+	url := fmt.Sprintf(FeedURLFormat, date.UTC().Format("2006-01-02"))
+	resp, err := f.HTTPClient.Get(url)
+	if err != nil {
+		return nil, fmt.Errorf("epss: fetching %s: %w", url, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("epss: unexpected status %d fetching %s", resp.StatusCode, url)
+	}
+
+	gz, err := gzip.NewReader(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("epss: decompressing feed: %w", err)
+	}
+	defer gz.Close()
+
+	return parseCSV(gz)
+}
+
+// parseCSV parses the EPSS feed body. The feed begins with a comment
+// line (model metadata) followed by a `cve,epss,percentile` header, so
+// blank/comment lines are skipped before the header is located.
+func parseCSV(r io.Reader) ([]Score, error) {
+	reader := csv.NewReader(r)
+	reader.Comment = '#'
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("epss: reading header: %w", err)
+	}
+
+	cveCol, epssCol, percentileCol := -1, -1, -1
+	for i, col := range header {
+		switch col {
+		case "cve":
+			cveCol = i
+		case "epss":
+			epssCol = i
+		case "percentile":
+			percentileCol = i
+		}
+	}
+	if cveCol == -1 || epssCol == -1 || percentileCol == -1 {
+		return nil, fmt.Errorf("epss: unexpected header %v", header)
+	}
+
+	var scores []Score
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("epss: reading row: %w", err)
+		}
+
+		score, err := strconv.ParseFloat(record[epssCol], 32)
+		if err != nil {
+			return nil, fmt.Errorf("epss: parsing score for %s: %w", record[cveCol], err)
+		}
+		percentile, err := strconv.ParseFloat(record[percentileCol], 32)
+		if err != nil {
+			return nil, fmt.Errorf("epss: parsing percentile for %s: %w", record[cveCol], err)
+		}
+
+		scores = append(scores, Score{
+			CveName:    record[cveCol],
+			EpssScore:  float32(score),
+			Percentile: float32(percentile),
+		})
+	}
+	return scores, nil
+}
+
+// UpsertScores writes scores onto the matching cve rows (by name).
+// CVEs not already present in the table are ignored - EPSS enrichment
+// only updates rows that ingestion has already created.
+func UpsertScores(conn *gorm.DB, scores []Score) error {
+	return conn.Transaction(func(tx *gorm.DB) error {
+		for _, score := range scores {
+			res := tx.Table("cve").Where("name = ?", score.CveName).
+				Updates(map[string]interface{}{"epss_score": score.EpssScore, "epss_percentile": score.Percentile})
+			if res.Error != nil {
+				return fmt.Errorf("epss: upserting score for %s: %w", score.CveName, res.Error)
+			}
+		}
+		return nil
+	})
+}
@@ -0,0 +1,35 @@
+package epss
+
+import (
+	"strings"
+	"testing"
+)
+
+const sampleFeed = `#model_version:v2023.03.01,score_date:2024-01-01T00:00:00+0000
+cve,epss,percentile
+CVE-2024-0001,0.97531,0.99982
+CVE-2024-0002,0.00042,0.10123
+`
+
+func TestParseCSV(t *testing.T) {
+	scores, err := parseCSV(strings.NewReader(sampleFeed))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(scores) != 2 {
+		t.Fatalf("expected 2 scores, got %d", len(scores))
+	}
+
+	if scores[0].CveName != "CVE-2024-0001" || scores[0].EpssScore != 0.97531 || scores[0].Percentile != 0.99982 {
+		t.Fatalf("unexpected first row: %+v", scores[0])
+	}
+	if scores[1].CveName != "CVE-2024-0002" || scores[1].EpssScore != 0.00042 || scores[1].Percentile != 0.10123 {
+		t.Fatalf("unexpected second row: %+v", scores[1])
+	}
+}
+
+func TestParseCSVMissingHeaderColumn(t *testing.T) {
+	if _, err := parseCSV(strings.NewReader("cve,epss\nCVE-2024-0001,0.5\n")); err == nil {
+		t.Fatal("expected an error for a feed missing the percentile column")
+	}
+}
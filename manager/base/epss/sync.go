@@ -0,0 +1,21 @@
+package epss
+
+import (
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Sync fetches the EPSS feed published for date and upserts it onto
+// the cve table, meant to run once a day after the feed publishes.
+func Sync(conn *gorm.DB, fetcher *Fetcher, date time.Time) error {
+	scores, err := fetcher.FetchDaily(date)
+	if err != nil {
+		return fmt.Errorf("epss: sync: %w", err)
+	}
+	if err := UpsertScores(conn, scores); err != nil {
+		return fmt.Errorf("epss: sync: %w", err)
+	}
+	return nil
+}
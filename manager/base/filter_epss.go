@@ -0,0 +1,28 @@
+package base
+
+import "gorm.io/gorm"
+
+// EpssScoreQuery is the `epss_score` filter key, a range of two floats
+// between 0.0 and 1.0
+const EpssScoreQuery = "epss_score"
+
+// ExploitLikelyQuery is the `exploit_likely` convenience filter key,
+// shorthand for epss_score >= ExploitLikelyThreshold
+const ExploitLikelyQuery = "exploit_likely"
+
+// ExploitLikelyThreshold is the EPSS score floor `exploit_likely=true`
+// expands to
+const ExploitLikelyThreshold = 0.5
+
+// EpssScore represents the `epss_score` filter (also used to expand
+// the `exploit_likely` convenience filter)
+type EpssScore struct {
+	RawFilter
+	ScoreFrom float32
+	ScoreTo   float32
+}
+
+// Apply adds the EPSS score range condition to the query
+func (f *EpssScore) Apply(tx *gorm.DB) *gorm.DB {
+	return tx.Where("cve.epss_score BETWEEN ? AND ?", f.ScoreFrom, f.ScoreTo)
+}
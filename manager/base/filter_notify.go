@@ -0,0 +1,23 @@
+package base
+
+import (
+	"app/base/models"
+
+	"gorm.io/gorm"
+)
+
+// NotifySeverityMinQuery is the `notify_severity_min` filter key,
+// restricting results to CVEs at or above a configured severity floor
+// for notification purposes
+const NotifySeverityMinQuery = "notify_severity_min"
+
+// NotifySeverityMin represents the `notify_severity_min` filter
+type NotifySeverityMin struct {
+	RawFilter
+	Floor models.Severity
+}
+
+// Apply adds the minimum notification severity condition to the query
+func (f *NotifySeverityMin) Apply(tx *gorm.DB) *gorm.DB {
+	return tx.Where("cve.severity >= ?", f.Floor)
+}
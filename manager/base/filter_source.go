@@ -0,0 +1,21 @@
+package base
+
+import "gorm.io/gorm"
+
+// SourceQuery is the `source` filter key, restricting results to CVEs
+// whose cve.source column (the enrichment source that last wrote any of
+// the row's enriched fields) is mitre, nvd or redhat. It does not swap
+// in another source's severity/score values; the row keeps the values
+// stored on it.
+const SourceQuery = "source"
+
+// EnrichmentSource represents the `source` filter
+type EnrichmentSource struct {
+	RawFilter
+	Source string
+}
+
+// Apply adds the enrichment source condition to the query
+func (f *EnrichmentSource) Apply(tx *gorm.DB) *gorm.DB {
+	return tx.Where("cve.source = ?", f.Source)
+}
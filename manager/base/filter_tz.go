@@ -0,0 +1,30 @@
+package base
+
+// TZQuery is the `tz` query parameter: an IANA timezone name (e.g.
+// "America/New_York") that relative date tokens in date-range filters
+// ("today", "yesterday", "this-week", "last-quarter") anchor to instead
+// of UTC. It has no WHERE clause of its own, so it's never part of an
+// endpoint's allowedFilters list - MergeTimezone folds it into the
+// date-range filters that do before they're parsed.
+const TZQuery = "tz"
+
+// MergeTimezone splices the tz filter's value, if present, onto every
+// dateRangeKey already present in rawFilters, matching the optional
+// 3rd member ParseDateRange accepts. Call it once, right after the
+// request's raw filters are collected, before ParseFilter/ApplyFilters
+// run over them.
+func MergeTimezone(rawFilters map[string][]string, dateRangeKeys ...string) {
+	tz, ok := rawFilters[TZQuery]
+	if !ok || len(tz) == 0 {
+		return
+	}
+	for _, key := range dateRangeKeys {
+		values, ok := rawFilters[key]
+		if !ok {
+			continue
+		}
+		merged := make([]string, len(values), len(values)+1)
+		copy(merged, values)
+		rawFilters[key] = append(merged, tz[0])
+	}
+}
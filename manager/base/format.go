@@ -0,0 +1,22 @@
+package base
+
+// Streaming data formats, extending the JSONFormat/CSVFormat pair with
+// formats that can be written to the response as the query cursor is
+// consumed instead of being buffered fully in memory.
+const (
+	NDJSONFormat = iota + 2
+	CSVGzipFormat
+	NDJSONGzipFormat
+	XLSXFormat
+)
+
+// IsStreamable reports whether format must be written row-by-row from
+// a cursor rather than built in memory up front.
+func IsStreamable(format uint64) bool {
+	switch format {
+	case NDJSONFormat, CSVGzipFormat, NDJSONGzipFormat, XLSXFormat:
+		return true
+	default:
+		return false
+	}
+}
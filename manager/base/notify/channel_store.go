@@ -0,0 +1,94 @@
+package notify
+
+import (
+	"app/base/models"
+	"fmt"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// parseSeverityFloor maps a stored severity_floor string onto the
+// local severity enum, defaulting to NotSet (every event passes) for
+// an empty or unrecognized value.
+func parseSeverityFloor(raw string) models.Severity {
+	switch strings.ToLower(raw) {
+	case "none":
+		return models.None
+	case "low":
+		return models.Low
+	case "medium":
+		return models.Medium
+	case "moderate":
+		return models.Moderate
+	case "important":
+		return models.Important
+	case "high":
+		return models.High
+	case "critical":
+		return models.Critical
+	default:
+		return models.NotSet
+	}
+}
+
+// NotificationChannel is a row in the notification_channel table: a
+// channel registered via POST /notifications/channels, later loaded
+// back by LoadChannels to build the Notifiers a Dispatcher fans out
+// to.
+type NotificationChannel struct {
+	ID            int64  `json:"id" gorm:"primaryKey"`
+	AccountID     int64  `json:"account_id"`
+	Name          string `json:"name"`
+	Type          string `json:"type"`
+	URL           string `json:"url"`
+	Template      string `json:"template"`
+	SeverityFloor string `json:"severity_floor"`
+	// From/To map onto from_address/to_address columns since "from" and
+	// "to" are reserved words in Postgres and can't be used unquoted as
+	// column names.
+	From     string `json:"from,omitempty" gorm:"column:from_address"`
+	To       string `json:"to,omitempty" gorm:"column:to_address"`
+	SMTPAddr string `json:"smtp_addr,omitempty"`
+}
+
+// TableName overrides gorm's pluralized default so the struct maps
+// onto the existing notification_channel table.
+func (NotificationChannel) TableName() string {
+	return "notification_channel"
+}
+
+// ToConfig converts a persisted channel row into the ChannelConfig
+// BuildNotifier expects.
+func (c NotificationChannel) ToConfig() ChannelConfig {
+	return ChannelConfig{
+		Name:          c.Name,
+		Type:          c.Type,
+		URL:           c.URL,
+		Template:      c.Template,
+		SeverityFloor: parseSeverityFloor(c.SeverityFloor),
+		From:          c.From,
+		To:            c.To,
+		SMTPAddr:      c.SMTPAddr,
+	}
+}
+
+// LoadChannels reads every channel registered for accountID and builds
+// their Notifiers, closing the loop between POST /notifications/channels
+// and actual delivery.
+func LoadChannels(conn *gorm.DB, accountID int64) ([]Notifier, error) {
+	var channels []NotificationChannel
+	if err := conn.Where("account_id = ?", accountID).Find(&channels).Error; err != nil {
+		return nil, fmt.Errorf("notify: loading channels for account %d: %w", accountID, err)
+	}
+
+	notifiers := make([]Notifier, 0, len(channels))
+	for _, channel := range channels {
+		notifier, err := BuildNotifier(channel.ToConfig())
+		if err != nil {
+			return nil, fmt.Errorf("notify: building notifier %q: %w", channel.Name, err)
+		}
+		notifiers = append(notifiers, notifier)
+	}
+	return notifiers, nil
+}
@@ -0,0 +1,168 @@
+package notify
+
+import (
+	"app/base/models"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/smtp"
+	"text/template"
+)
+
+// ChannelConfig is the YAML shape of a single configured channel:
+// type (slack, splunk, webhook, email), the delivery URL, a
+// text/template body, and the minimum severity that triggers it.
+type ChannelConfig struct {
+	Name          string          `yaml:"name"`
+	Type          string          `yaml:"type"`
+	URL           string          `yaml:"url"`
+	Template      string          `yaml:"template"`
+	SeverityFloor models.Severity `yaml:"severity_floor"`
+	// From/To/SMTPAddr are only used by the "email" channel type: From
+	// and To are rendered into the message headers, SMTPAddr is the
+	// mail relay's host:port (URL is left unused for that type).
+	From     string `yaml:"from"`
+	To       string `yaml:"to"`
+	SMTPAddr string `yaml:"smtp_addr"`
+}
+
+// BuildNotifier constructs the Notifier implementation for a channel's
+// configured type.
+func BuildNotifier(cfg ChannelConfig) (Notifier, error) {
+	tmpl, err := template.New(cfg.Name).Parse(cfg.Template)
+	if err != nil {
+		return nil, fmt.Errorf("notify: parsing template for channel %q: %w", cfg.Name, err)
+	}
+
+	switch cfg.Type {
+	case "slack":
+		return &webhookNotifier{name: cfg.Name, url: cfg.URL, tmpl: tmpl, floor: cfg.SeverityFloor, client: http.DefaultClient}, nil
+	case "splunk":
+		return &splunkNotifier{name: cfg.Name, url: cfg.URL, tmpl: tmpl, floor: cfg.SeverityFloor, client: http.DefaultClient}, nil
+	case "webhook":
+		return &webhookNotifier{name: cfg.Name, url: cfg.URL, tmpl: tmpl, floor: cfg.SeverityFloor, client: http.DefaultClient}, nil
+	case "email":
+		if cfg.From == "" || cfg.To == "" || cfg.SMTPAddr == "" {
+			return nil, fmt.Errorf("notify: email channel %q requires from, to and smtp_addr", cfg.Name)
+		}
+		return &emailNotifier{name: cfg.Name, smtpAddr: cfg.SMTPAddr, from: cfg.From, to: cfg.To, tmpl: tmpl, floor: cfg.SeverityFloor}, nil
+	default:
+		return nil, fmt.Errorf("notify: unknown channel type %q", cfg.Type)
+	}
+}
+
+func render(tmpl *template.Template, event Event) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, event.Data); err != nil {
+		return nil, fmt.Errorf("notify: rendering template: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// webhookNotifier posts the rendered template body to a generic
+// webhook URL (also used for Slack incoming webhooks).
+type webhookNotifier struct {
+	name   string
+	url    string
+	tmpl   *template.Template
+	floor  models.Severity
+	client *http.Client
+}
+
+func (n *webhookNotifier) Name() string { return n.name }
+
+func (n *webhookNotifier) Notify(ctx context.Context, event Event) error {
+	if !event.SeverityAtLeast(n.floor) {
+		return nil
+	}
+	body, err := render(n.tmpl, event)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, n.url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("notify[%s]: building request: %w", n.name, err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("notify[%s]: delivering: %w", n.name, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("notify[%s]: unexpected status %d", n.name, resp.StatusCode)
+	}
+	return nil
+}
+
+// splunkNotifier posts the rendered template body to a Splunk HTTP
+// Event Collector endpoint.
+type splunkNotifier struct {
+	name   string
+	url    string
+	tmpl   *template.Template
+	floor  models.Severity
+	client *http.Client
+}
+
+func (n *splunkNotifier) Name() string { return n.name }
+
+func (n *splunkNotifier) Notify(ctx context.Context, event Event) error {
+	if !event.SeverityAtLeast(n.floor) {
+		return nil
+	}
+	rendered, err := render(n.tmpl, event)
+	if err != nil {
+		return err
+	}
+	payload, err := json.Marshal(map[string]interface{}{"event": string(rendered)})
+	if err != nil {
+		return fmt.Errorf("notify[%s]: encoding HEC payload: %w", n.name, err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, n.url, bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("notify[%s]: building request: %w", n.name, err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("notify[%s]: delivering: %w", n.name, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("notify[%s]: unexpected status %d", n.name, resp.StatusCode)
+	}
+	return nil
+}
+
+// emailNotifier sends the rendered template body as a plaintext email
+// via SMTP.
+type emailNotifier struct {
+	name     string
+	smtpAddr string
+	from     string
+	to       string
+	tmpl     *template.Template
+	floor    models.Severity
+}
+
+func (n *emailNotifier) Name() string { return n.name }
+
+func (n *emailNotifier) Notify(ctx context.Context, event Event) error {
+	if !event.SeverityAtLeast(n.floor) {
+		return nil
+	}
+	body, err := render(n.tmpl, event)
+	if err != nil {
+		return err
+	}
+
+	message := fmt.Sprintf("From: %s\r\nTo: %s\r\nSubject: vuln4shift: %s affecting %s\r\n\r\n%s",
+		n.from, n.to, event.CveName, event.ClusterUUID, body)
+	if err := smtp.SendMail(n.smtpAddr, nil, n.from, []string{n.to}, []byte(message)); err != nil {
+		return fmt.Errorf("notify[%s]: sending mail: %w", n.name, err)
+	}
+	return nil
+}
@@ -0,0 +1,41 @@
+package notify
+
+import (
+	"fmt"
+	"os"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Config is the top level YAML document listing every configured
+// notification channel.
+type Config struct {
+	Channels []ChannelConfig `yaml:"channels"`
+}
+
+// LoadConfig reads and parses a channel config YAML file from path.
+func LoadConfig(path string) (*Config, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("notify: reading config %q: %w", path, err)
+	}
+
+	var cfg Config
+	if err := yaml.Unmarshal(raw, &cfg); err != nil {
+		return nil, fmt.Errorf("notify: parsing config %q: %w", path, err)
+	}
+	return &cfg, nil
+}
+
+// BuildNotifiers constructs a Notifier for every channel in the config.
+func BuildNotifiers(cfg *Config) ([]Notifier, error) {
+	notifiers := make([]Notifier, 0, len(cfg.Channels))
+	for _, channel := range cfg.Channels {
+		notifier, err := BuildNotifier(channel)
+		if err != nil {
+			return nil, err
+		}
+		notifiers = append(notifiers, notifier)
+	}
+	return notifiers, nil
+}
@@ -0,0 +1,55 @@
+package notify
+
+import (
+	"log"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// NotificationFailure is a row in the notification_failure table,
+// recorded whenever a channel exhausts its retries for an event.
+type NotificationFailure struct {
+	ID          int64     `json:"id" gorm:"primaryKey"`
+	AccountID   int64     `json:"account_id"`
+	ChannelName string    `json:"channel_name"`
+	ClusterUUID string    `json:"cluster_uuid"`
+	CveName     string    `json:"cve_name"`
+	Error       string    `json:"error"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// TableName overrides gorm's pluralized default so the struct maps
+// onto the existing notification_failure table.
+func (NotificationFailure) TableName() string {
+	return "notification_failure"
+}
+
+// DeadLetterLog persists deliveries that exhausted every retry so
+// they're visible via GET /notifications/failures.
+type DeadLetterLog interface {
+	Record(event Event, channelName string, deliveryErr error)
+}
+
+// GormDeadLetterLog is the DeadLetterLog backed by the
+// notification_failure table.
+type GormDeadLetterLog struct {
+	Conn *gorm.DB
+}
+
+// Record inserts a row describing the exhausted delivery. Errors
+// writing the dead-letter row are only logged, never returned; a
+// failing dead-letter write must not crash the dispatch loop.
+func (l *GormDeadLetterLog) Record(event Event, channelName string, deliveryErr error) {
+	err := l.Conn.Create(&NotificationFailure{
+		AccountID:   event.AccountID,
+		ChannelName: channelName,
+		ClusterUUID: event.ClusterUUID,
+		CveName:     event.CveName,
+		Error:       deliveryErr.Error(),
+		CreatedAt:   time.Now().UTC(),
+	}).Error
+	if err != nil {
+		log.Printf("notify: recording dead-letter row for channel %q: %s", channelName, err.Error())
+	}
+}
@@ -0,0 +1,89 @@
+// Package notify fans out events to configurable notification sinks
+// (Slack, Splunk HEC, generic webhooks, email) for clusters affected by
+// a CVE at or above a configurable severity threshold. SyncAccount
+// dispatches every currently affecting cluster/CVE pairing on each run;
+// it does not track which pairings were already notified on earlier
+// runs.
+package notify
+
+import (
+	"app/base/models"
+	"context"
+	"time"
+)
+
+// Event describes a single cluster/CVE affecting pairing to notify
+// channels about.
+type Event struct {
+	AccountID   int64
+	ClusterUUID string
+	CveName     string
+	Severity    models.Severity
+	OccurredAt  time.Time
+	// Data carries the row rendered into channel templates with
+	// text/template. SyncAccount passes its affectingRow (ClusterUUID,
+	// CveName, Severity), not clusters.GetClusterCvesSelect.
+	Data interface{}
+}
+
+// Notifier is implemented by every notification sink plugin.
+type Notifier interface {
+	Name() string
+	Notify(ctx context.Context, event Event) error
+}
+
+// SeverityAtLeast reports whether the event severity meets or exceeds
+// the given floor, used to gate delivery per channel.
+func (e Event) SeverityAtLeast(floor models.Severity) bool {
+	return e.Severity >= floor
+}
+
+// Dispatcher fans an Event out to every registered Notifier, retrying
+// failed deliveries with exponential backoff and recording exhausted
+// retries to a dead-letter log.
+type Dispatcher struct {
+	Notifiers  []Notifier
+	DeadLetter DeadLetterLog
+	MaxRetries int
+	BaseDelay  time.Duration
+}
+
+// NewDispatcher creates a Dispatcher with the repo's default retry
+// policy (1 attempt plus up to 5 retries, 500ms base delay doubling per
+// retry).
+func NewDispatcher(notifiers []Notifier, deadLetter DeadLetterLog) *Dispatcher {
+	return &Dispatcher{
+		Notifiers:  notifiers,
+		DeadLetter: deadLetter,
+		MaxRetries: 5,
+		BaseDelay:  500 * time.Millisecond,
+	}
+}
+
+// Dispatch delivers the event to every notifier whose configured
+// severity floor the event meets, recording exhausted deliveries to the
+// dead-letter log instead of returning an error.
+func (d *Dispatcher) Dispatch(ctx context.Context, event Event) {
+	for _, notifier := range d.Notifiers {
+		if err := d.deliverWithRetry(ctx, notifier, event); err != nil {
+			d.DeadLetter.Record(event, notifier.Name(), err)
+		}
+	}
+}
+
+func (d *Dispatcher) deliverWithRetry(ctx context.Context, notifier Notifier, event Event) error {
+	var err error
+	for attempt := 0; attempt <= d.MaxRetries; attempt++ {
+		if attempt > 0 {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(d.BaseDelay << (attempt - 1)):
+			}
+		}
+		if err = notifier.Notify(ctx, event); err == nil {
+			return nil
+		}
+	}
+	return err
+}
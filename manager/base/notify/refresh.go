@@ -0,0 +1,73 @@
+package notify
+
+import (
+	"app/base/models"
+	"context"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// affectingRow is one cluster/CVE pairing read back from the same
+// join BuildClusterCvesQuery uses, scoped to every cluster on an
+// account instead of a single one.
+type affectingRow struct {
+	ClusterUUID string
+	CveName     string
+	Severity    models.Severity
+}
+
+// SyncAccount loads accountID's registered channels, finds every
+// cluster/CVE pairing currently affecting that account at or above
+// each channel's severity floor, and dispatches an Event per pairing.
+// This is the nightly hook into the cluster/image CVE refresh path:
+// run once per account after cluster_image/image_cve are refreshed.
+func SyncAccount(ctx context.Context, conn *gorm.DB, accountID int64) error {
+	notifiers, err := LoadChannels(conn, accountID)
+	if err != nil {
+		return err
+	}
+	if len(notifiers) == 0 {
+		return nil
+	}
+
+	var rows []affectingRow
+	res := conn.Table("cve").
+		Select("cluster.uuid AS cluster_uuid, cve.name AS cve_name, cve.severity AS severity").
+		Joins("JOIN image_cve ON cve.id = image_cve.cve_id").
+		Joins("JOIN cluster_image ON cluster_image.image_id = image_cve.image_id").
+		Joins("JOIN cluster ON cluster_image.cluster_id = cluster.id").
+		Where("cluster.account_id = ?", accountID).
+		Group("cluster.uuid, cve.name, cve.severity").
+		Find(&rows)
+	if res.Error != nil {
+		return fmt.Errorf("notify: loading affecting CVEs for account %d: %w", accountID, res.Error)
+	}
+
+	dispatcher := NewDispatcher(notifiers, &GormDeadLetterLog{Conn: conn})
+	now := time.Now().UTC()
+	for _, row := range rows {
+		dispatcher.Dispatch(ctx, Event{
+			AccountID:   accountID,
+			ClusterUUID: row.ClusterUUID,
+			CveName:     row.CveName,
+			Severity:    row.Severity,
+			OccurredAt:  now,
+			Data:        row,
+		})
+	}
+	return nil
+}
+
+// AccountIDsWithChannels returns every account that has at least one
+// registered notification channel, the nightly sync command's work
+// list.
+func AccountIDsWithChannels(conn *gorm.DB) ([]int64, error) {
+	var accountIDs []int64
+	res := conn.Model(&NotificationChannel{}).Distinct("account_id").Pluck("account_id", &accountIDs)
+	if res.Error != nil {
+		return nil, fmt.Errorf("notify: listing accounts with channels: %w", res.Error)
+	}
+	return accountIDs, nil
+}
@@ -3,6 +3,7 @@ package base
 import (
 	"app/base/models"
 	"errors"
+	"fmt"
 	"strconv"
 	"strings"
 	"time"
@@ -37,35 +38,120 @@ func ParseBoolArray(rawValues []string, limit *int) ([]bool, error) {
 	return res, nil
 }
 
-// ParseDateRange parses 2 member array with date range
+// relativeDateTokens are the natural-language date tokens accepted by
+// ParseDateRange, alongside plain YYYY-MM-DD dates
+var relativeDateTokens = []string{"now", "today", "yesterday", "this-week", "last-quarter"}
+
+// ParseDateRange parses a 2 member array with a date range, and an
+// optional 3rd member naming an IANA timezone that "today"/"yesterday"/
+// "this-week"/"last-quarter" anchor to (default UTC). Each of the first
+// two members accepts either a YYYY-MM-DD date or one of
+// relativeDateTokens; only "now" may be suffixed with "-Nd"/"-Ny"
+// (e.g. "now-7d", "now-1y"). The 3rd member
+// is never supplied directly by API callers: MergeTimezone splices the
+// standalone `tz` filter onto the raw values before ParseFilter runs,
+// so this signature stays a plain date range to its caller here while
+// tz remains its own documented query parameter.
 func ParseDateRange(rawValues []string) ([]time.Time, error) {
-	if len(rawValues) != 2 {
+	if len(rawValues) != 2 && len(rawValues) != 3 {
 		return []time.Time{}, errors.New("invalid date range format")
 	}
 
-	var dateFrom time.Time
-	if rawValues[0] == "" {
-		dateFrom = time.Date(1970, time.January, 1, 0, 0, 0, 0, time.UTC)
-	} else {
-		d, err := time.Parse(DateFormat, rawValues[0])
+	loc := time.UTC
+	if len(rawValues) == 3 && rawValues[2] != "" {
+		l, err := time.LoadLocation(rawValues[2])
 		if err != nil {
-			return []time.Time{}, errors.New("invalid date format")
+			return []time.Time{}, errors.New("invalid tz parameter")
 		}
-		dateFrom = d
+		loc = l
 	}
 
-	var dateTo time.Time
-	if rawValues[1] == "" {
-		dateTo = time.Date(2070, time.January, 1, 0, 0, 0, 0, time.UTC)
-	} else {
-		d, err := time.Parse(DateFormat, rawValues[1])
+	dateFrom, err := parseRelativeOrAbsoluteDate(rawValues[0], loc, time.Date(1970, time.January, 1, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		return []time.Time{}, fmt.Errorf("invalid published from date: %w", err)
+	}
+
+	dateTo, err := parseRelativeOrAbsoluteDate(rawValues[1], loc, time.Date(2070, time.January, 1, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		return []time.Time{}, fmt.Errorf("invalid published to date: %w", err)
+	}
+
+	return []time.Time{dateFrom, dateTo}, nil
+}
+
+// parseRelativeOrAbsoluteDate parses a single date range slot: empty
+// returns def, a YYYY-MM-DD string parses as an absolute UTC date, and
+// a relative token is resolved against the current time in loc before
+// being converted back to UTC.
+func parseRelativeOrAbsoluteDate(raw string, loc *time.Location, def time.Time) (time.Time, error) {
+	if raw == "" {
+		return def, nil
+	}
+
+	if d, err := time.Parse(DateFormat, raw); err == nil {
+		return d, nil
+	}
+
+	return parseRelativeDate(raw, loc)
+}
+
+// parseRelativeDate resolves one of relativeDateTokens ("now"
+// optionally suffixed with "-Nd"/"-Ny") against the current time in loc
+func parseRelativeDate(raw string, loc *time.Location) (time.Time, error) {
+	now := time.Now().In(loc)
+	midnight := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, loc)
+
+	token := raw
+	var offset string
+	if idx := strings.Index(raw, "-"); idx > 0 && raw[:idx] == "now" {
+		token = raw[:idx]
+		offset = raw[idx:]
+	}
+
+	switch token {
+	case "now":
+		if offset == "" {
+			return now.UTC(), nil
+		}
+		d, err := parseRelativeOffset(offset)
 		if err != nil {
-			return []time.Time{}, errors.New("invalid date format")
+			return time.Time{}, err
 		}
-		dateTo = d
+		return now.Add(-d).UTC(), nil
+	case "today":
+		return midnight.UTC(), nil
+	case "yesterday":
+		return midnight.AddDate(0, 0, -1).UTC(), nil
+	case "this-week":
+		daysSinceMonday := (int(midnight.Weekday()) + 6) % 7
+		return midnight.AddDate(0, 0, -daysSinceMonday).UTC(), nil
+	case "last-quarter":
+		currentQuarterStartMonth := ((int(midnight.Month())-1)/3)*3 + 1
+		quarterStart := time.Date(midnight.Year(), time.Month(currentQuarterStartMonth), 1, 0, 0, 0, 0, loc)
+		return quarterStart.AddDate(0, -3, 0).UTC(), nil
+	default:
+		return time.Time{}, fmt.Errorf("unrecognized date token %q, expected one of %s or YYYY-MM-DD", raw, strings.Join(relativeDateTokens, ", "))
 	}
+}
 
-	return []time.Time{dateFrom, dateTo}, nil
+// parseRelativeOffset parses the "-Nd"/"-Ny" suffix of a "now-..." token
+func parseRelativeOffset(raw string) (time.Duration, error) {
+	if len(raw) < 3 {
+		return 0, fmt.Errorf("invalid relative date offset %q", raw)
+	}
+	unit := raw[len(raw)-1]
+	amount, err := strconv.Atoi(raw[1 : len(raw)-1])
+	if err != nil {
+		return 0, fmt.Errorf("invalid relative date offset %q", raw)
+	}
+	switch unit {
+	case 'd':
+		return time.Duration(amount) * 24 * time.Hour, nil
+	case 'y':
+		return time.Duration(amount) * 365 * 24 * time.Hour, nil
+	default:
+		return 0, fmt.Errorf("invalid relative date offset unit in %q, expected d or y", raw)
+	}
 }
 
 // ParseSeverity parses array of severity strings
@@ -149,6 +235,42 @@ func ParseCvssScoreRange(rawValues []string) ([]float32, error) {
 	return []float32{float32(scoreFrom), float32(scoreTo)}, nil
 }
 
+// ParseEpssScoreRange parses array of two member range of EPSS score
+// floats, analogous to ParseCvssScoreRange but bounded to 0.0-1.0
+func ParseEpssScoreRange(rawValues []string) ([]float32, error) {
+	if len(rawValues) != 2 {
+		return []float32{}, errors.New("invalid epss_score range format")
+	}
+
+	var scoreFrom float32
+	if rawValues[0] == "" {
+		scoreFrom = 0.0
+	} else {
+		f, err := strconv.ParseFloat(rawValues[0], 32)
+		if err != nil {
+			return []float32{}, errors.New("invalid epss score from value")
+		}
+		scoreFrom = float32(f)
+	}
+
+	var scoreTo float32
+	if rawValues[1] == "" {
+		scoreTo = 1.0
+	} else {
+		f, err := strconv.ParseFloat(rawValues[1], 32)
+		if err != nil {
+			return []float32{}, errors.New("invalid epss score to value")
+		}
+		scoreTo = float32(f)
+	}
+
+	if scoreFrom < 0.0 || scoreTo > 1.0 {
+		return []float32{}, errors.New("epss score must be between 0.0 and 1.0")
+	}
+
+	return []float32{scoreFrom, scoreTo}, nil
+}
+
 // ParseUint parses string to int64
 func ParseUint(rawValues []string) (uint64, error) {
 	if len(rawValues) != 1 {
@@ -187,11 +309,47 @@ func ParseDataFormat(rawValues []string) (uint64, error) {
 		return JSONFormat, nil
 	case "csv":
 		return CSVFormat, nil
+	case "ndjson":
+		return NDJSONFormat, nil
+	case "csv.gz":
+		return CSVGzipFormat, nil
+	case "ndjson.gz":
+		return NDJSONGzipFormat, nil
+	case "xlsx":
+		return XLSXFormat, nil
 	default:
 		return 0, errors.New("Invalid data format argument")
 	}
 }
 
+// ParseEnrichmentSource parses the `source` filter value, naming the
+// enrichment source (mitre, nvd, redhat) whose CVE rows a query keeps
+func ParseEnrichmentSource(rawValues []string) (string, error) {
+	if len(rawValues) != 1 {
+		return "", errors.New("invalid source parameter")
+	}
+	switch strings.ToLower(rawValues[0]) {
+	case "mitre", "nvd", "redhat":
+		return strings.ToLower(rawValues[0]), nil
+	default:
+		return "", errors.New("invalid source argument")
+	}
+}
+
+// ParseNotifySeverityMin parses the `notify_severity_min` filter value,
+// reusing the single-value severity vocabulary accepted by
+// ParseSeverity
+func ParseNotifySeverityMin(rawValues []string) (models.Severity, error) {
+	if len(rawValues) != 1 {
+		return models.NotSet, errors.New("invalid notify_severity_min parameter")
+	}
+	severities, err := ParseSeverity(rawValues)
+	if err != nil {
+		return models.NotSet, errors.New("invalid notify_severity_min argument")
+	}
+	return severities[0], nil
+}
+
 // ParseCapitalArray parses string array to capital string array
 func ParseCapitalArray(rawValues []string) []string {
 	var res []string
@@ -218,7 +376,7 @@ func ParseFilter(rawName string, rawValues []string) (Filter, error) {
 	case PublishedQuery:
 		dateRange, err := ParseDateRange(parsedValues)
 		if err != nil {
-			return &CvePublishDate{}, errors.New("invalid published parameter format")
+			return &CvePublishDate{}, err
 		}
 		return &CvePublishDate{RawFilter{raw, parsedValues}, dateRange[0], dateRange[1]}, nil
 	case SeverityQuery:
@@ -283,6 +441,34 @@ func ParseFilter(rawName string, rawValues []string) (Filter, error) {
 		return &Status{RawFilter{raw, parsedValues}, statuses}, nil
 	case VersionQuery:
 		return &Version{RawFilter{raw, parsedValues}, parsedValues}, nil
+	case SourceQuery:
+		source, err := ParseEnrichmentSource(parsedValues)
+		if err != nil {
+			return &EnrichmentSource{}, err
+		}
+		return &EnrichmentSource{RawFilter{raw, parsedValues}, source}, nil
+	case NotifySeverityMinQuery:
+		floor, err := ParseNotifySeverityMin(parsedValues)
+		if err != nil {
+			return &NotifySeverityMin{}, err
+		}
+		return &NotifySeverityMin{RawFilter{raw, parsedValues}, floor}, nil
+	case EpssScoreQuery:
+		scoreRange, err := ParseEpssScoreRange(parsedValues)
+		if err != nil {
+			return &EpssScore{}, err
+		}
+		return &EpssScore{RawFilter{raw, parsedValues}, scoreRange[0], scoreRange[1]}, nil
+	case ExploitLikelyQuery:
+		arrLen := 1
+		likely, err := ParseBoolArray(parsedValues, &arrLen)
+		if err != nil {
+			return &EpssScore{}, errors.New("invalid exploit_likely parameter")
+		}
+		if !likely[0] {
+			return &EpssScore{RawFilter{raw, parsedValues}, 0.0, 1.0}, nil
+		}
+		return &EpssScore{RawFilter{raw, parsedValues}, ExploitLikelyThreshold, 1.0}, nil
 	default:
 		return &Search{}, ErrInvalidFilterArgument
 	}
@@ -0,0 +1,77 @@
+package base
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseDateRangeRelativeTokens(t *testing.T) {
+	from, err := parseRelativeOrAbsoluteDate("now-7d", time.UTC, time.Time{})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if want := time.Now().UTC().Add(-7 * 24 * time.Hour); want.Sub(from).Abs() > time.Minute {
+		t.Fatalf("expected now-7d close to %s, got %s", want, from)
+	}
+
+	to, err := parseRelativeOrAbsoluteDate("2024-01-01", time.UTC, time.Time{})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if to.Year() != 2024 || to.Month() != time.January || to.Day() != 1 {
+		t.Fatalf("expected 2024-01-01, got %s", to)
+	}
+}
+
+func TestParseDateRangeInvalidToken(t *testing.T) {
+	if _, err := parseRelativeOrAbsoluteDate("not-a-token", time.UTC, time.Time{}); err == nil {
+		t.Fatal("expected an error for an unrecognized date token")
+	}
+}
+
+func TestParseDateRangeHonorsTimezone(t *testing.T) {
+	loc, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	todayUTC, err := parseRelativeOrAbsoluteDate("today", time.UTC, time.Time{})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	todayNY, err := parseRelativeOrAbsoluteDate("today", loc, time.Time{})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if todayUTC.Equal(todayNY) {
+		t.Fatal("expected midnight in UTC and America/New_York to differ")
+	}
+}
+
+func TestMergeTimezoneAppendsToDateRangeKeys(t *testing.T) {
+	filters := map[string][]string{
+		PublishedQuery: {"now-30d,now"},
+		TZQuery:        {"America/New_York"},
+	}
+	MergeTimezone(filters, PublishedQuery)
+
+	want := []string{"now-30d,now", "America/New_York"}
+	got := filters[PublishedQuery]
+	if len(got) != len(want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("expected %v, got %v", want, got)
+		}
+	}
+}
+
+func TestMergeTimezoneNoopWithoutTZ(t *testing.T) {
+	filters := map[string][]string{PublishedQuery: {"now-30d,now"}}
+	MergeTimezone(filters, PublishedQuery)
+
+	if len(filters[PublishedQuery]) != 1 {
+		t.Fatalf("expected published filter untouched, got %v", filters[PublishedQuery])
+	}
+}
@@ -0,0 +1,152 @@
+// Package preset persists parsed filter query strings under a short
+// opaque ID per account, so a set of filters (e.g. "critical CVEs,
+// last 7d") can be shared and re-applied via ?preset=<short_id>
+// instead of a long query string.
+package preset
+
+import (
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const shortIDAlphabet = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
+const shortIDLength = 8
+
+// ErrNotFound is returned when a preset short ID doesn't belong to the
+// requesting account.
+var ErrNotFound = errors.New("preset: not found")
+
+// FilterPreset is a row in the filter_preset table.
+type FilterPreset struct {
+	ID         int64     `json:"-" gorm:"primaryKey"`
+	AccountID  int64     `json:"-"`
+	ShortID    string    `json:"short_id"`
+	Name       string    `json:"name"`
+	QueryJSON  string    `json:"-"`
+	CreatedAt  time.Time `json:"created_at"`
+	LastUsedAt time.Time `json:"last_used_at"`
+}
+
+// TableName overrides gorm's pluralized default so the struct maps
+// onto the existing filter_preset table.
+func (FilterPreset) TableName() string {
+	return "filter_preset"
+}
+
+// Query decodes the stored filters back into a raw query string map,
+// the same shape ParseFilter/ListQuery already consume.
+func (p FilterPreset) Query() (map[string][]string, error) {
+	var values map[string][]string
+	if err := json.Unmarshal([]byte(p.QueryJSON), &values); err != nil {
+		return nil, fmt.Errorf("preset: decoding stored query: %w", err)
+	}
+	return values, nil
+}
+
+// Store persists and looks up filter presets.
+type Store struct {
+	Conn *gorm.DB
+}
+
+// NewStore creates a Store backed by conn.
+func NewStore(conn *gorm.DB) *Store {
+	return &Store{Conn: conn}
+}
+
+// Create persists a new preset for accountID from the given raw filter
+// values (as returned by base.GetRequestedFilters), returning the
+// generated short ID.
+func (s *Store) Create(accountID int64, name string, values map[string][]string) (*FilterPreset, error) {
+	encoded, err := json.Marshal(values)
+	if err != nil {
+		return nil, fmt.Errorf("preset: encoding query: %w", err)
+	}
+
+	shortID, err := generateShortID()
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	preset := FilterPreset{
+		AccountID:  accountID,
+		ShortID:    shortID,
+		Name:       name,
+		QueryJSON:  string(encoded),
+		CreatedAt:  now,
+		LastUsedAt: now,
+	}
+	if err := s.Conn.Create(&preset).Error; err != nil {
+		return nil, fmt.Errorf("preset: creating: %w", err)
+	}
+	return &preset, nil
+}
+
+// Get looks up a preset by short ID, scoped to accountID, and marks it
+// used.
+func (s *Store) Get(accountID int64, shortID string) (*FilterPreset, error) {
+	var preset FilterPreset
+	res := s.Conn.Where("account_id = ? AND short_id = ?", accountID, shortID).First(&preset)
+	if errors.Is(res.Error, gorm.ErrRecordNotFound) {
+		return nil, ErrNotFound
+	} else if res.Error != nil {
+		return nil, fmt.Errorf("preset: fetching %q: %w", shortID, res.Error)
+	}
+
+	s.Conn.Model(&preset).Update("last_used_at", time.Now().UTC())
+	return &preset, nil
+}
+
+// List returns every preset for accountID whose name matches search
+// (a case-insensitive substring match); an empty search returns all.
+func (s *Store) List(accountID int64, search string) ([]FilterPreset, error) {
+	var presets []FilterPreset
+	query := s.Conn.Where("account_id = ?", accountID)
+	if search != "" {
+		query = query.Where("name ILIKE ?", "%"+search+"%")
+	}
+	if err := query.Order("last_used_at desc").Find(&presets).Error; err != nil {
+		return nil, fmt.Errorf("preset: listing: %w", err)
+	}
+	return presets, nil
+}
+
+// Delete removes a preset by short ID, scoped to accountID.
+func (s *Store) Delete(accountID int64, shortID string) error {
+	res := s.Conn.Where("account_id = ? AND short_id = ?", accountID, shortID).Delete(&FilterPreset{})
+	if res.Error != nil {
+		return fmt.Errorf("preset: deleting %q: %w", shortID, res.Error)
+	}
+	if res.RowsAffected == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// ParseRawQuery decodes a raw query string (e.g. the POST /filters
+// request body) into the map[string][]string shape used elsewhere.
+func ParseRawQuery(rawQuery string) (map[string][]string, error) {
+	values, err := url.ParseQuery(rawQuery)
+	if err != nil {
+		return nil, fmt.Errorf("preset: parsing query string: %w", err)
+	}
+	return map[string][]string(values), nil
+}
+
+func generateShortID() (string, error) {
+	buf := make([]byte, shortIDLength)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("preset: generating short id: %w", err)
+	}
+	id := make([]byte, shortIDLength)
+	for i, b := range buf {
+		id[i] = shortIDAlphabet[int(b)%len(shortIDAlphabet)]
+	}
+	return string(id), nil
+}
@@ -0,0 +1,245 @@
+package base
+
+import (
+	"compress/gzip"
+	"database/sql"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/xuri/excelize/v2"
+	"gorm.io/gorm"
+)
+
+// streamBatchSize is how many rows are written between calls to
+// http.Flusher.Flush(), keeping exports of tens of thousands of rows
+// from buffering entirely in memory on either end of the connection.
+const streamBatchSize = 500
+
+// ErrUnsupportedStreamFormat is returned when StreamQuery is asked to
+// stream a format that isn't one of the registered streamable formats
+var ErrUnsupportedStreamFormat = errors.New("base: unsupported streaming format")
+
+// WantsStreamingReport reports whether the raw request filters select
+// report=true together with a data_format that must be streamed from a
+// cursor instead of buffered in memory.
+func WantsStreamingReport(rawFilters map[string][]string) (bool, uint64, error) {
+	formatValues, ok := rawFilters[DataFormatQuery]
+	if !ok {
+		return false, 0, nil
+	}
+	format, err := ParseDataFormat(ParseCommaParams(formatValues))
+	if err != nil {
+		return false, 0, err
+	}
+	if !IsStreamable(format) {
+		return false, 0, nil
+	}
+
+	reportValues, ok := rawFilters[ReportQuery]
+	if !ok {
+		return false, 0, nil
+	}
+	limit := 1
+	report, err := ParseBoolArray(ParseCommaParams(reportValues), &limit)
+	if err != nil {
+		return false, 0, err
+	}
+	return report[0], format, nil
+}
+
+func extensionFor(format uint64) string {
+	switch format {
+	case NDJSONFormat:
+		return "ndjson"
+	case CSVGzipFormat:
+		return "csv.gz"
+	case NDJSONGzipFormat:
+		return "ndjson.gz"
+	case XLSXFormat:
+		return "xlsx"
+	default:
+		return "dat"
+	}
+}
+
+// StreamQuery streams rows scanned from query directly into the
+// response writer as format, instead of loading the full result set
+// into memory first. It's used by report=true endpoints that can
+// return tens of thousands of rows.
+func StreamQuery[T any](ctx *gin.Context, query *gorm.DB, format uint64, endpoint string, accountID int64) error {
+	filename := fmt.Sprintf("%s_%d_%s.%s", endpoint, accountID, time.Now().UTC().Format("20060102T150405Z"), extensionFor(format))
+	ctx.Writer.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+
+	rows, err := query.Rows()
+	if err != nil {
+		return fmt.Errorf("base: querying rows to stream: %w", err)
+	}
+	defer rows.Close()
+
+	switch format {
+	case NDJSONFormat:
+		return streamNDJSON[T](ctx, query, rows, ctx.Writer)
+	case NDJSONGzipFormat:
+		gz := gzip.NewWriter(ctx.Writer)
+		defer gz.Close()
+		return streamNDJSON[T](ctx, query, rows, gz)
+	case CSVGzipFormat:
+		gz := gzip.NewWriter(ctx.Writer)
+		defer gz.Close()
+		return streamCSV[T](ctx, query, rows, gz)
+	case XLSXFormat:
+		return streamXLSX[T](ctx, query, rows, ctx.Writer)
+	default:
+		return ErrUnsupportedStreamFormat
+	}
+}
+
+func streamNDJSON[T any](ctx *gin.Context, query *gorm.DB, rows *sql.Rows, w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	count := 0
+	for rows.Next() {
+		var row T
+		if err := query.ScanRows(rows, &row); err != nil {
+			return fmt.Errorf("base: scanning row to stream: %w", err)
+		}
+		if err := encoder.Encode(row); err != nil {
+			return fmt.Errorf("base: encoding ndjson row: %w", err)
+		}
+		count++
+		if count%streamBatchSize == 0 {
+			ctx.Writer.Flush()
+		}
+	}
+	return rows.Err()
+}
+
+func streamCSV[T any](ctx *gin.Context, query *gorm.DB, rows *sql.Rows, w io.Writer) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(csvHeader[T]()); err != nil {
+		return fmt.Errorf("base: writing csv header: %w", err)
+	}
+
+	count := 0
+	for rows.Next() {
+		var row T
+		if err := query.ScanRows(rows, &row); err != nil {
+			return fmt.Errorf("base: scanning row to stream: %w", err)
+		}
+		if err := writer.Write(csvRecord(row)); err != nil {
+			return fmt.Errorf("base: writing csv row: %w", err)
+		}
+		count++
+		if count%streamBatchSize == 0 {
+			writer.Flush()
+			ctx.Writer.Flush()
+		}
+	}
+	writer.Flush()
+	return rows.Err()
+}
+
+func streamXLSX[T any](ctx *gin.Context, query *gorm.DB, rows *sql.Rows, w io.Writer) error {
+	file := excelize.NewFile()
+	streamWriter, err := file.NewStreamWriter("Sheet1")
+	if err != nil {
+		return fmt.Errorf("base: creating xlsx stream writer: %w", err)
+	}
+
+	header := csvHeader[T]()
+	headerRow := make([]interface{}, len(header))
+	for i, col := range header {
+		headerRow[i] = col
+	}
+	if err := streamWriter.SetRow("A1", headerRow); err != nil {
+		return fmt.Errorf("base: writing xlsx header: %w", err)
+	}
+
+	rowNum := 2
+	for rows.Next() {
+		var row T
+		if err := query.ScanRows(rows, &row); err != nil {
+			return fmt.Errorf("base: scanning row to stream: %w", err)
+		}
+		record := csvRecord(row)
+		cells := make([]interface{}, len(record))
+		for i, val := range record {
+			cells[i] = val
+		}
+		cell, err := excelize.CoordinatesToCellName(1, rowNum)
+		if err != nil {
+			return fmt.Errorf("base: computing xlsx cell: %w", err)
+		}
+		if err := streamWriter.SetRow(cell, cells); err != nil {
+			return fmt.Errorf("base: writing xlsx row: %w", err)
+		}
+		rowNum++
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	if err := streamWriter.Flush(); err != nil {
+		return fmt.Errorf("base: flushing xlsx stream: %w", err)
+	}
+	return file.Write(w)
+}
+
+// csvHeader derives the csv column names of T from its `csv` struct
+// tags, matching the tags already used by csv.Marshal elsewhere.
+func csvHeader[T any]() []string {
+	var zero T
+	t := reflect.TypeOf(zero)
+	header := make([]string, 0, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		tag := t.Field(i).Tag.Get("csv")
+		if tag == "" {
+			tag = t.Field(i).Name
+		}
+		header = append(header, tag)
+	}
+	return header
+}
+
+// csvRecord renders every field of row as a string, in struct field
+// order, matching csvHeader. A field is rendered through fmt.Stringer
+// when it (or its address) implements one, so enum fields like
+// Severity print their name instead of their underlying int, matching
+// the buffered CSV export path.
+func csvRecord(row interface{}) []string {
+	// Copy row into an addressable value so stringify can reach
+	// pointer-receiver String methods on non-pointer fields too.
+	v := reflect.New(reflect.TypeOf(row)).Elem()
+	v.Set(reflect.ValueOf(row))
+	record := make([]string, 0, v.NumField())
+	for i := 0; i < v.NumField(); i++ {
+		field := v.Field(i)
+		if field.Kind() == reflect.Ptr {
+			if field.IsNil() {
+				record = append(record, "")
+				continue
+			}
+			field = field.Elem()
+		}
+		record = append(record, stringify(field))
+	}
+	return record
+}
+
+// stringify renders field via fmt.Stringer when field or its address
+// implements one, falling back to fmt's default formatting otherwise.
+func stringify(field reflect.Value) string {
+	if stringer, ok := field.Interface().(fmt.Stringer); ok {
+		return stringer.String()
+	}
+	if field.CanAddr() {
+		if stringer, ok := field.Addr().Interface().(fmt.Stringer); ok {
+			return stringer.String()
+		}
+	}
+	return fmt.Sprintf("%v", field.Interface())
+}
@@ -0,0 +1,60 @@
+package base
+
+import (
+	"reflect"
+	"testing"
+)
+
+type enumValue int
+
+func (e enumValue) String() string {
+	if e == 1 {
+		return "high"
+	}
+	return "unknown"
+}
+
+type pointerEnumValue int
+
+func (e *pointerEnumValue) String() string {
+	if *e == 2 {
+		return "low"
+	}
+	return "unknown"
+}
+
+func TestCsvRecordUsesStringer(t *testing.T) {
+	value := enumValue(1)
+	record := csvRecord(struct {
+		Name string
+		Enum *enumValue
+	}{Name: "CVE-2024-1", Enum: &value})
+
+	expected := []string{"CVE-2024-1", "high"}
+	if !reflect.DeepEqual(record, expected) {
+		t.Fatalf("expected %v, got %v", expected, record)
+	}
+}
+
+func TestCsvRecordUsesPointerReceiverStringer(t *testing.T) {
+	value := pointerEnumValue(2)
+	record := csvRecord(struct {
+		Enum *pointerEnumValue
+	}{Enum: &value})
+
+	expected := []string{"low"}
+	if !reflect.DeepEqual(record, expected) {
+		t.Fatalf("expected %v, got %v", expected, record)
+	}
+}
+
+func TestCsvRecordNilPointerIsEmptyString(t *testing.T) {
+	record := csvRecord(struct {
+		Enum *enumValue
+	}{Enum: nil})
+
+	expected := []string{""}
+	if !reflect.DeepEqual(record, expected) {
+		t.Fatalf("expected %v, got %v", expected, record)
+	}
+}
@@ -3,6 +3,7 @@ package clusters
 import (
 	"app/base/models"
 	"app/manager/base"
+	"app/manager/base/preset"
 	"net/http"
 	"time"
 
@@ -12,17 +13,19 @@ import (
 )
 
 var getClusterCvesAllowedFilters = []string{base.SearchQuery, base.PublishedQuery,
-	base.SeverityQuery, base.CvssScoreQuery, base.DataFormatQuery}
+	base.SeverityQuery, base.CvssScoreQuery, base.DataFormatQuery, base.SourceQuery,
+	base.EpssScoreQuery, base.ExploitLikelyQuery, base.NotifySeverityMinQuery}
 
 var getClusterCvesFilterArgs = map[string]interface{}{
 	base.SortFilterArgs: base.SortArgs{
 		SortableColumns: map[string]string{
-			"id":             "cve.id",
-			"cvss_score":     "GREATEST(cve.cvss3_score, cve.cvss2_score)",
-			"severity":       "cve.severity",
-			"publish_date":   "cve.public_date",
-			"synopsis":       "cve.name",
-			"images_exposed": "images_exposed",
+			"id":              "cve.id",
+			"cvss_score":      "GREATEST(cve.cvss3_score, cve.cvss2_score)",
+			"severity":        "cve.severity",
+			"publish_date":    "cve.public_date",
+			"synopsis":        "cve.name",
+			"images_exposed":  "images_exposed",
+			"epss_percentile": "cve.epss_percentile",
 		},
 		DefaultSortable: []base.SortItem{{Column: "id", Desc: false}},
 	},
@@ -33,13 +36,15 @@ var getClusterCvesFilterArgs = map[string]interface{}{
 // @Description CVE in cluster data
 // @Description presents in response
 type GetClusterCvesSelect struct {
-	Cvss2Score    *float32         `json:"cvss2_score" csv:"cvss2_score"`
-	Cvss3Score    *float32         `json:"cvss3_score" csv:"cvss3_score"`
-	Description   *string          `json:"description" csv:"description"`
-	Severity      *models.Severity `json:"severity" csv:"severity"`
-	PublicDate    *time.Time       `json:"publish_date" csv:"publish_date"`
-	Name          *string          `json:"synopsis" csv:"synopsis"`
-	ImagesExposed *int64           `json:"images_exposed" csv:"images_exposed"`
+	Cvss2Score     *float32         `json:"cvss2_score" csv:"cvss2_score"`
+	Cvss3Score     *float32         `json:"cvss3_score" csv:"cvss3_score"`
+	Description    *string          `json:"description" csv:"description"`
+	Severity       *models.Severity `json:"severity" csv:"severity"`
+	PublicDate     *time.Time       `json:"publish_date" csv:"publish_date"`
+	Name           *string          `json:"synopsis" csv:"synopsis"`
+	ImagesExposed  *int64           `json:"images_exposed" csv:"images_exposed"`
+	EpssScore      *float32         `json:"epss_score" csv:"epss_score"`
+	EpssPercentile *float32         `json:"epss_percentile" csv:"epss_percentile"`
 }
 
 type GetClusterCvesResponse struct {
@@ -61,11 +66,16 @@ type GetClusterCvesResponse struct {
 // @Param search          query string   false "cve name/desc search"                                 example(CVE-2021-)
 // @Param limit           query int      false "limit per page"                                       example(10) minimum(0) maximum(100)
 // @Param offset          query int      false "page offset"                                          example(10) minimum(0)
-// @Param data_format     query string   false "data section format"                                  enums(json,csv)
-// @Param report          query bool     false "overrides limit and offset to return everything"
-// @Param published       query []string false "CVE publish date: (from date),(to date)"              collectionFormat(multi) collectionFormat(csv) minItems(2) maxItems(2)
+// @Param data_format     query string   false "data section format"                                  enums(json,csv,ndjson,csv.gz,ndjson.gz,xlsx)
+// @Param report          query bool     false "overrides limit and offset to return everything; ndjson/csv.gz/ndjson.gz/xlsx formats stream the response instead of buffering it"
+// @Param published       query []string false "CVE publish date: (from date),(to date)"              collectionFormat(multi) collectionFormat(csv) minItems(2) maxItems(2) example(now-30d,now)
+// @Param tz              query string   false "IANA timezone that relative published tokens anchor to" example(America/New_York)
 // @Param severity        query []string false "array of severity names"                              enums(NotSet,None,Low,Medium,Moderate,Important,High,Critical)
 // @Param cvss_score      query []number false "CVSS score of CVE: (from float),(to float)"           collectionFormat(multi) collectionFormat(csv) minItems(2) maxItems(2)
+// @Param epss_score      query []number false "EPSS score of CVE: (from float),(to float)"           collectionFormat(multi) collectionFormat(csv) minItems(2) maxItems(2) minimum(0) maximum(1)
+// @Param exploit_likely  query bool     false "shorthand for epss_score >= 0.5"
+// @Param source          query string   false "only CVEs whose enrichment source matches"            enums(mitre,nvd,redhat)
+// @Param preset          query string   false "short ID of a saved filter preset; explicit params win over it" example(aB3xQ9pL)
 // @router /clusters/{cluster_id}/cves [get]
 // @success 200 {object} GetClusterCvesResponse
 // @failure 400 {object} base.Error
@@ -96,8 +106,44 @@ func (c *Controller) GetClusterCves(ctx *gin.Context) {
 	}
 
 	filters := base.GetRequestedFilters(ctx)
+	base.MergeTimezone(filters, base.PublishedQuery)
+	if presetID := ctx.Query("preset"); presetID != "" {
+		if err := expandPreset(c.Conn, accountID, presetID, filters); err != nil {
+			status := http.StatusInternalServerError
+			msg := "Internal server error"
+			if err == preset.ErrNotFound {
+				status = http.StatusBadRequest
+				msg = "preset does not exist"
+			} else {
+				c.Logger.Errorf("Internal server error: %s", err.Error())
+			}
+			ctx.AbortWithStatusJSON(status, base.BuildErrorResponse(status, msg))
+			return
+		}
+	}
+
 	query := c.BuildClusterCvesQuery(accountID, clusterID)
 
+	if streaming, format, err := base.WantsStreamingReport(filters); err != nil {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, base.BuildErrorResponse(http.StatusBadRequest, err.Error()))
+		return
+	} else if streaming {
+		query, err = base.ApplyFilters(query, getClusterCvesAllowedFilters, filters)
+		if err != nil {
+			ctx.AbortWithStatusJSON(http.StatusBadRequest, base.BuildErrorResponse(http.StatusBadRequest, err.Error()))
+			return
+		}
+		query, err = base.ApplySort(query, getClusterCvesFilterArgs[base.SortFilterArgs].(base.SortArgs), filters)
+		if err != nil {
+			ctx.AbortWithStatusJSON(http.StatusBadRequest, base.BuildErrorResponse(http.StatusBadRequest, err.Error()))
+			return
+		}
+		if err := base.StreamQuery[GetClusterCvesSelect](ctx, query, format, "cluster_cves", accountID); err != nil {
+			c.Logger.Errorf("Error streaming cluster cves report: %s", err.Error())
+		}
+		return
+	}
+
 	dataRes := []GetClusterCvesSelect{}
 	usedFilters, totalItems, inputErr, dbErr := base.ListQuery(query, getClusterCvesAllowedFilters, filters, getClusterCvesFilterArgs, &dataRes)
 	if inputErr != nil {
@@ -123,6 +169,26 @@ func (c *Controller) GetClusterCves(ctx *gin.Context) {
 	ctx.JSON(http.StatusOK, resp)
 }
 
+// expandPreset loads the named filter preset and merges it into
+// filters, letting explicit query parameters win over stored ones.
+func expandPreset(conn *gorm.DB, accountID int64, shortID string, filters map[string][]string) error {
+	saved, err := preset.NewStore(conn).Get(accountID, shortID)
+	if err != nil {
+		return err
+	}
+
+	presetValues, err := saved.Query()
+	if err != nil {
+		return err
+	}
+	for name, values := range presetValues {
+		if _, exists := filters[name]; !exists {
+			filters[name] = values
+		}
+	}
+	return nil
+}
+
 // ClusterExists, checks if cluster exists in db with given accid and clusterid
 func (c *Controller) ClusterExists(accountID int64, clusterID uuid.UUID) (bool, error) {
 	res := c.Conn.Table("cluster").Where("account_id = ? AND uuid = ?", accountID, clusterID).Limit(1).Find(&struct{}{})
@@ -135,7 +201,7 @@ func (c *Controller) ClusterExists(accountID int64, clusterID uuid.UUID) (bool,
 func (c *Controller) BuildClusterCvesQuery(accountID int64, clusterID uuid.UUID) *gorm.DB {
 	return c.Conn.Table("cve").
 		Select(`cve.cvss2_score, cve.cvss3_score, cve.description, cve.severity,
-			cve.public_date, cve.name,
+			cve.public_date, cve.name, cve.epss_score, cve.epss_percentile,
 			COUNT(DISTINCT cluster_image.image_id) as images_exposed`).
 		Joins("JOIN image_cve ON cve.id = image_cve.cve_id").
 		Joins("JOIN cluster_image ON cluster_image.image_id = image_cve.image_id").
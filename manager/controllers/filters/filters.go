@@ -0,0 +1,218 @@
+package filters
+
+import (
+	"app/manager/base"
+	"app/manager/base/preset"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// Controller holds dependencies for the /filters preset endpoints
+type Controller struct {
+	Conn   *gorm.DB
+	Logger base.Logger
+}
+
+// CreatePresetRequest is the body accepted by POST /filters. Query
+// carries either a raw query string ("severity=critical&published=now-7d,now")
+// or an already-parsed JSON object of filter name to values.
+type CreatePresetRequest struct {
+	Name  string          `json:"name" binding:"required"`
+	Query json.RawMessage `json:"query" binding:"required"`
+}
+
+// CreatePresetResponse is the response body of POST /filters
+type CreatePresetResponse struct {
+	ShortID string `json:"short_id"`
+	Name    string `json:"name"`
+}
+
+// CreatePreset represents POST /filters endpoint controller.
+//
+// @id CreatePreset
+// @summary Save a set of filters under a short, shareable ID
+// @security RhIdentity || BasicAuth
+// @Tags filters
+// @description Endpoint persisting a named set of filters, later re-appliable via ?preset=<short_id>
+// @accept json
+// @produce json
+// @Param body body CreatePresetRequest true "preset definition"
+// @router /filters [post]
+// @success 200 {object} CreatePresetResponse
+// @failure 400 {object} base.Error
+// @failure 500 {object} base.Error
+func (c *Controller) CreatePreset(ctx *gin.Context) {
+	accountID := ctx.GetInt64("account_id")
+
+	var req CreatePresetRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, base.BuildErrorResponse(http.StatusBadRequest, err.Error()))
+		return
+	}
+
+	values, err := decodePresetQuery(req.Query)
+	if err != nil {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, base.BuildErrorResponse(http.StatusBadRequest, err.Error()))
+		return
+	}
+
+	store := preset.NewStore(c.Conn)
+	saved, err := store.Create(accountID, req.Name, values)
+	if err != nil {
+		ctx.AbortWithStatusJSON(
+			http.StatusInternalServerError,
+			base.BuildErrorResponse(http.StatusInternalServerError, "Internal server error"),
+		)
+		c.Logger.Errorf("Database error: %s", err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, CreatePresetResponse{ShortID: saved.ShortID, Name: saved.Name})
+}
+
+// GetPresetResponse is the response body of GET /filters/{id}
+type GetPresetResponse struct {
+	Name  string `json:"name"`
+	Query string `json:"query"`
+}
+
+// GetPreset represents GET /filters/{id} endpoint controller.
+//
+// @id GetPreset
+// @summary Get a saved filter preset as a canonical query string
+// @security RhIdentity || BasicAuth
+// @Tags filters
+// @description Endpoint returning a saved preset's name and canonical query string
+// @accept */*
+// @produce json
+// @Param id path string true "preset short ID"
+// @router /filters/{id} [get]
+// @success 200 {object} GetPresetResponse
+// @failure 404 {object} base.Error
+// @failure 500 {object} base.Error
+func (c *Controller) GetPreset(ctx *gin.Context) {
+	accountID := ctx.GetInt64("account_id")
+	shortID := ctx.Param("id")
+
+	store := preset.NewStore(c.Conn)
+	saved, err := store.Get(accountID, shortID)
+	if err == preset.ErrNotFound {
+		ctx.AbortWithStatusJSON(http.StatusNotFound, base.BuildErrorResponse(http.StatusNotFound, "preset does not exist"))
+		return
+	} else if err != nil {
+		ctx.AbortWithStatusJSON(
+			http.StatusInternalServerError,
+			base.BuildErrorResponse(http.StatusInternalServerError, "Internal server error"),
+		)
+		c.Logger.Errorf("Database error: %s", err.Error())
+		return
+	}
+
+	values, err := saved.Query()
+	if err != nil {
+		ctx.AbortWithStatusJSON(
+			http.StatusInternalServerError,
+			base.BuildErrorResponse(http.StatusInternalServerError, "Internal server error"),
+		)
+		c.Logger.Errorf("Internal server error: %s", err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, GetPresetResponse{Name: saved.Name, Query: encodeQueryString(values)})
+}
+
+// ListPresetsResponse is the response body of GET /filters
+type ListPresetsResponse struct {
+	Data []preset.FilterPreset `json:"data"`
+}
+
+// ListPresets represents GET /filters endpoint controller.
+//
+// @id ListPresets
+// @summary List saved filter presets for the calling account
+// @security RhIdentity || BasicAuth
+// @Tags filters
+// @description Endpoint returning every saved preset, optionally filtered by name
+// @accept */*
+// @produce json
+// @Param search query string false "substring match on preset name"
+// @router /filters [get]
+// @success 200 {object} ListPresetsResponse
+// @failure 500 {object} base.Error
+func (c *Controller) ListPresets(ctx *gin.Context) {
+	accountID := ctx.GetInt64("account_id")
+
+	store := preset.NewStore(c.Conn)
+	presets, err := store.List(accountID, ctx.Query("search"))
+	if err != nil {
+		ctx.AbortWithStatusJSON(
+			http.StatusInternalServerError,
+			base.BuildErrorResponse(http.StatusInternalServerError, "Internal server error"),
+		)
+		c.Logger.Errorf("Database error: %s", err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, ListPresetsResponse{Data: presets})
+}
+
+// DeletePreset represents DELETE /filters/{id} endpoint controller.
+//
+// @id DeletePreset
+// @summary Delete a saved filter preset
+// @security RhIdentity || BasicAuth
+// @Tags filters
+// @description Endpoint deleting a saved preset by short ID
+// @accept */*
+// @produce json
+// @Param id path string true "preset short ID"
+// @router /filters/{id} [delete]
+// @success 204
+// @failure 404 {object} base.Error
+// @failure 500 {object} base.Error
+func (c *Controller) DeletePreset(ctx *gin.Context) {
+	accountID := ctx.GetInt64("account_id")
+	shortID := ctx.Param("id")
+
+	store := preset.NewStore(c.Conn)
+	err := store.Delete(accountID, shortID)
+	if err == preset.ErrNotFound {
+		ctx.AbortWithStatusJSON(http.StatusNotFound, base.BuildErrorResponse(http.StatusNotFound, "preset does not exist"))
+		return
+	} else if err != nil {
+		ctx.AbortWithStatusJSON(
+			http.StatusInternalServerError,
+			base.BuildErrorResponse(http.StatusInternalServerError, "Internal server error"),
+		)
+		c.Logger.Errorf("Database error: %s", err.Error())
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// decodePresetQuery accepts either a JSON-encoded raw query string
+// ("severity=critical&published=now-7d,now") or an already-parsed
+// object of filter name to values.
+func decodePresetQuery(raw json.RawMessage) (map[string][]string, error) {
+	var rawQuery string
+	if err := json.Unmarshal(raw, &rawQuery); err == nil {
+		return preset.ParseRawQuery(rawQuery)
+	}
+
+	var values map[string][]string
+	if err := json.Unmarshal(raw, &values); err != nil {
+		return nil, errors.New("invalid query: expected a raw query string or an object of filter values")
+	}
+	return values, nil
+}
+
+func encodeQueryString(values map[string][]string) string {
+	query := url.Values(values)
+	return query.Encode()
+}
@@ -0,0 +1,113 @@
+package notifications
+
+import (
+	"app/manager/base"
+	"app/manager/base/notify"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// Controller holds dependencies for the /notifications endpoints
+type Controller struct {
+	Conn   *gorm.DB
+	Logger base.Logger
+}
+
+// RegisterChannelRequest is the body accepted by POST /notifications/channels
+type RegisterChannelRequest struct {
+	Name          string `json:"name" binding:"required"`
+	Type          string `json:"type" binding:"required"`
+	URL           string `json:"url"`
+	Template      string `json:"template" binding:"required"`
+	SeverityFloor string `json:"severity_floor" binding:"required"`
+	From          string `json:"from,omitempty"`
+	To            string `json:"to,omitempty"`
+	SMTPAddr      string `json:"smtp_addr,omitempty"`
+}
+
+// RegisterChannel represents POST /notifications/channels endpoint controller.
+//
+// @id RegisterChannel
+// @summary Register a notification channel for the calling account
+// @security RhIdentity || BasicAuth
+// @Tags notifications
+// @description Registers a Slack/Splunk/webhook/email channel that receives events when a cluster becomes affected by a CVE at or above severity_floor
+// @accept json
+// @produce json
+// @Param body body RegisterChannelRequest true "channel definition"
+// @router /notifications/channels [post]
+// @success 200 {object} notify.NotificationChannel
+// @failure 400 {object} base.Error
+// @failure 500 {object} base.Error
+func (c *Controller) RegisterChannel(ctx *gin.Context) {
+	accountID := ctx.GetInt64("account_id")
+
+	var req RegisterChannelRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, base.BuildErrorResponse(http.StatusBadRequest, err.Error()))
+		return
+	}
+
+	channel := notify.NotificationChannel{
+		AccountID:     accountID,
+		Name:          req.Name,
+		Type:          req.Type,
+		URL:           req.URL,
+		Template:      req.Template,
+		SeverityFloor: req.SeverityFloor,
+		From:          req.From,
+		To:            req.To,
+		SMTPAddr:      req.SMTPAddr,
+	}
+
+	if _, err := notify.BuildNotifier(channel.ToConfig()); err != nil {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, base.BuildErrorResponse(http.StatusBadRequest, err.Error()))
+		return
+	}
+
+	if err := c.Conn.Create(&channel).Error; err != nil {
+		ctx.AbortWithStatusJSON(
+			http.StatusInternalServerError,
+			base.BuildErrorResponse(http.StatusInternalServerError, "Internal server error"),
+		)
+		c.Logger.Errorf("Database error: %s", err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, channel)
+}
+
+// ListFailuresResponse is the response body of GET /notifications/failures
+type ListFailuresResponse struct {
+	Data []notify.NotificationFailure `json:"data"`
+}
+
+// ListFailures represents GET /notifications/failures endpoint controller.
+//
+// @id ListFailures
+// @summary List failed notification deliveries for the calling account
+// @security RhIdentity || BasicAuth
+// @Tags notifications
+// @description Endpoint returning notification deliveries that exhausted every retry
+// @accept */*
+// @produce json
+// @router /notifications/failures [get]
+// @success 200 {object} ListFailuresResponse
+// @failure 500 {object} base.Error
+func (c *Controller) ListFailures(ctx *gin.Context) {
+	accountID := ctx.GetInt64("account_id")
+
+	var failures []notify.NotificationFailure
+	if err := c.Conn.Where("account_id = ?", accountID).Order("created_at desc").Find(&failures).Error; err != nil {
+		ctx.AbortWithStatusJSON(
+			http.StatusInternalServerError,
+			base.BuildErrorResponse(http.StatusInternalServerError, "Internal server error"),
+		)
+		c.Logger.Errorf("Database error: %s", err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, ListFailuresResponse{Data: failures})
+}